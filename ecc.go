@@ -0,0 +1,53 @@
+package sudokuconv
+
+import "github.com/pkg/errors"
+
+// ToBytesECC converts a solved board into ToBytes's compact
+// representation, framed with `parity` Reed-Solomon parity symbols over
+// GF(256) so FromBytesECC can correct up to parity/2 corrupted bytes in
+// transit or storage. The returned slice starts with a header byte
+// storing parity, so FromBytesECC can recover the payload length.
+func ToBytesECC(board [9][9]int, parity int) ([]byte, error) {
+	if parity <= 0 || parity > 255 {
+		return nil, errors.New("parity out of range")
+	}
+
+	payload, err := ToBytes(board)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload)+parity > 255 {
+		return nil, errors.New("parity too large for payload")
+	}
+
+	codeword := rsEncode(payload, parity)
+	out := make([]byte, len(codeword)+1)
+	out[0] = byte(parity)
+	copy(out[1:], codeword)
+	return out, nil
+}
+
+// FromBytesECC reverses ToBytesECC: it reads the parity symbol count
+// from the header byte, corrects up to parity/2 byte errors via
+// Reed-Solomon decoding, and converts the repaired payload back to a
+// board via FromBytes. An error is returned iff the header or payload
+// is malformed, or the errors present exceed the code's correction
+// capacity.
+func FromBytesECC(bytes []byte) ([9][9]int, error) {
+	if len(bytes) < 1 {
+		return [9][9]int{}, errors.New("not enough bytes")
+	}
+
+	parity := int(bytes[0])
+	codeword := bytes[1:]
+	if len(codeword) <= parity {
+		return [9][9]int{}, errors.New("not enough bytes")
+	}
+
+	payload, err := rsDecode(codeword, parity)
+	if err != nil {
+		return [9][9]int{}, errors.Wrap(err, "uncorrectable bytes")
+	}
+
+	return FromBytes(payload)
+}
@@ -0,0 +1,72 @@
+package sudokuconv_test
+
+import (
+	"testing"
+
+	"github.com/jraedisch/sudokuconv"
+)
+
+func TestToBytesECCRoundtrip(t *testing.T) {
+	const parity = 6
+	encoded, err := sudokuconv.ToBytesECC(working, parity)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Corrupt parity/2 bytes (the most this code can correct), skipping
+	// the header byte.
+	corrupted := append([]byte(nil), encoded...)
+	corrupted[1] ^= 0x01
+	corrupted[5] ^= 0xFF
+	corrupted[10] ^= 0x10
+
+	out, err := sudokuconv.FromBytesECC(corrupted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != working {
+		t.Errorf("unexpected board:\n%v\n%v", working, out)
+	}
+}
+
+func TestFromBytesECCTooManyErrors(t *testing.T) {
+	const parity = 4
+	encoded, err := sudokuconv.ToBytesECC(working, parity)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	corrupted := append([]byte(nil), encoded...)
+	corrupted[1] ^= 0x01
+	corrupted[5] ^= 0xFF
+	corrupted[10] ^= 0x10
+
+	if out, err := sudokuconv.FromBytesECC(corrupted); err == nil {
+		t.Errorf("expected error for more errors than parity/2, got board %v", out)
+	}
+}
+
+func TestToBytesECCInvalidParity(t *testing.T) {
+	if _, err := sudokuconv.ToBytesECC(working, 0); err == nil {
+		t.Error("expected error for zero parity")
+	}
+	if _, err := sudokuconv.ToBytesECC(emptyBoard, 2); err == nil {
+		t.Error("expected error for unsolved board")
+	}
+}
+
+func TestFromBytesECCMalformed(t *testing.T) {
+	tests := []struct {
+		id string
+		in []byte
+	}{
+		{id: "empty", in: []byte{}},
+		{id: "header without payload", in: []byte{4}},
+		{id: "payload shorter than parity", in: []byte{4, 1, 2, 3}},
+	}
+	for _, test := range tests {
+		if _, err := sudokuconv.FromBytesECC(test.in); err == nil {
+			t.Errorf("%s: expected error", test.id)
+		}
+	}
+}
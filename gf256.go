@@ -0,0 +1,59 @@
+package sudokuconv
+
+// gfPrimitivePoly is x^8+x^4+x^3+x^2+1, the primitive polynomial used to
+// build GF(256) for the Reed-Solomon coding in reedsolomon.go.
+const gfPrimitivePoly = 0x11D
+
+var (
+	gfExpTable [510]byte
+	gfLogTable [256]byte
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		gfExpTable[i] = byte(x)
+		gfLogTable[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= gfPrimitivePoly
+		}
+	}
+	for i := 255; i < 510; i++ {
+		gfExpTable[i] = gfExpTable[i-255]
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExpTable[int(gfLogTable[a])+int(gfLogTable[b])]
+}
+
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return gfExpTable[(int(gfLogTable[a])-int(gfLogTable[b])+255)%255]
+}
+
+// gfPow computes a^n. It is used with a == 2 to enumerate the powers of
+// the field's generator, alpha.
+func gfPow(a byte, n int) byte {
+	if a == 0 {
+		if n == 0 {
+			return 1
+		}
+		return 0
+	}
+	e := (int(gfLogTable[a]) * n) % 255
+	if e < 0 {
+		e += 255
+	}
+	return gfExpTable[e]
+}
+
+func gfInv(a byte) byte {
+	return gfExpTable[255-int(gfLogTable[a])]
+}
@@ -0,0 +1,475 @@
+package sudokuconv
+
+import (
+	"math/bits"
+
+	"github.com/pkg/errors"
+)
+
+// fullCandidateMask has bits 0-8 set, representing digits 1-9 all still
+// being possible in a cell.
+const fullCandidateMask uint16 = 0x1FF
+
+// Rule identifies the deduction technique that produced a Step.
+type Rule int
+
+const (
+	NakedSingle Rule = iota
+	HiddenSingle
+	NakedSubset
+	PointingSubset
+	BoxLineReduction
+	Backtracking
+)
+
+func (r Rule) String() string {
+	switch r {
+	case NakedSingle:
+		return "naked single"
+	case HiddenSingle:
+		return "hidden single"
+	case NakedSubset:
+		return "naked subset"
+	case PointingSubset:
+		return "pointing subset"
+	case BoxLineReduction:
+		return "box/line reduction"
+	case Backtracking:
+		return "backtracking"
+	default:
+		return "unknown rule"
+	}
+}
+
+// Step records a single deduction made by SolveLogical: Digit was
+// placed in, or eliminated as a candidate from, (Row, Col) because of
+// Rule, for the reason given in Reason. A Backtracking step names no
+// cell or digit: Row, Col and Digit are 0.
+type Step struct {
+	Rule   Rule
+	Row    int
+	Col    int
+	Digit  int
+	Reason string
+}
+
+// Trace is the ordered sequence of deductions SolveLogical made to
+// reach its result.
+type Trace []Step
+
+// Difficulty rates a puzzle by the hardest technique needed to solve
+// it.
+type Difficulty int
+
+const (
+	Easy Difficulty = iota
+	Medium
+	Hard
+	Evil
+)
+
+func (d Difficulty) String() string {
+	switch d {
+	case Easy:
+		return "Easy"
+	case Medium:
+		return "Medium"
+	case Hard:
+		return "Hard"
+	case Evil:
+		return "Evil"
+	default:
+		return "unknown difficulty"
+	}
+}
+
+// Difficulty derives t's difficulty from the strongest rule it
+// contains: Easy if only singles were needed, Medium if a naked
+// pair/triple was needed, Hard if a pointing pair or box/line reduction
+// was needed, and Evil if the logical rules stalled and SolveLogical
+// fell back to Solve's backtracking search.
+func (t Trace) Difficulty() Difficulty {
+	difficulty := Easy
+	for _, step := range t {
+		switch step.Rule {
+		case NakedSubset:
+			if difficulty < Medium {
+				difficulty = Medium
+			}
+		case PointingSubset, BoxLineReduction:
+			if difficulty < Hard {
+				difficulty = Hard
+			}
+		case Backtracking:
+			difficulty = Evil
+		}
+	}
+	return difficulty
+}
+
+// SolveLogical completes a 9x9 sudoku board using human-style
+// deduction rules, applied in order of increasing strength: naked
+// single, hidden single, naked pair/triple, pointing pair, and
+// box/line reduction. The cycle restarts from the top after every
+// successful deduction until no rule makes further progress. If the
+// board is not fully solved at that point, it falls back to Solve and
+// records a Backtracking step, so Trace.Difficulty reflects it. Blank
+// cells must be 0.
+func SolveLogical(board [9][9]int) ([9][9]int, Trace, error) {
+	if !validatePartial(board) {
+		return [9][9]int{}, nil, errors.New("board not valid")
+	}
+
+	candidates := initialCandidates(board)
+	var trace Trace
+
+	for {
+		if step, ok := findNakedSingle(board, candidates); ok {
+			apply(&board, &candidates, step)
+			trace = append(trace, step)
+			continue
+		}
+		if step, ok := findHiddenSingle(board, candidates); ok {
+			apply(&board, &candidates, step)
+			trace = append(trace, step)
+			continue
+		}
+		if eliminateNakedSubsets(board, &candidates, &trace) {
+			continue
+		}
+		if eliminatePointing(board, &candidates, &trace) {
+			continue
+		}
+		if eliminateBoxLine(board, &candidates, &trace) {
+			continue
+		}
+		break
+	}
+
+	if boardComplete(board) {
+		return board, trace, nil
+	}
+
+	solved, err := Solve(board)
+	if err != nil {
+		return [9][9]int{}, trace, err
+	}
+	trace = append(trace, Step{Rule: Backtracking, Reason: "logical rules stalled; completed via backtracking search"})
+	return solved, trace, nil
+}
+
+// initialCandidates builds the starting candidate grid: filled cells
+// carry only their own digit, blank cells start at fullCandidateMask
+// and then have every digit already placed in a peer eliminated.
+func initialCandidates(board [9][9]int) [9][9]uint16 {
+	var candidates [9][9]uint16
+	for r := 0; r < 9; r++ {
+		for c := 0; c < 9; c++ {
+			if board[r][c] == 0 {
+				candidates[r][c] = fullCandidateMask
+			} else {
+				candidates[r][c] = 1 << uint(board[r][c]-1)
+			}
+		}
+	}
+	for r := 0; r < 9; r++ {
+		for c := 0; c < 9; c++ {
+			if board[r][c] != 0 {
+				eliminateFromPeers(&candidates, r, c, candidates[r][c])
+			}
+		}
+	}
+	return candidates
+}
+
+// eliminateFromPeers clears mask's bits from every cell sharing a row,
+// column or block with (row, col), other than the cell itself.
+func eliminateFromPeers(candidates *[9][9]uint16, row, col int, mask uint16) {
+	for _, cell := range peers(row, col) {
+		candidates[cell[0]][cell[1]] &^= mask
+	}
+}
+
+func peers(row, col int) [][2]int {
+	seen := map[[2]int]bool{{row, col}: true}
+	var out [][2]int
+	add := func(r, c int) {
+		key := [2]int{r, c}
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		out = append(out, key)
+	}
+	for i := 0; i < 9; i++ {
+		add(row, i)
+		add(i, col)
+	}
+	for _, cell := range blockCells(row/3, col/3) {
+		add(cell[0], cell[1])
+	}
+	return out
+}
+
+// apply places step's digit on the board, collapses its candidates to
+// that single digit, and eliminates the digit from its peers.
+func apply(board *[9][9]int, candidates *[9][9]uint16, step Step) {
+	board[step.Row][step.Col] = step.Digit
+	mask := uint16(1) << uint(step.Digit-1)
+	candidates[step.Row][step.Col] = mask
+	eliminateFromPeers(candidates, step.Row, step.Col, mask)
+}
+
+func findNakedSingle(board [9][9]int, candidates [9][9]uint16) (Step, bool) {
+	for r := 0; r < 9; r++ {
+		for c := 0; c < 9; c++ {
+			if board[r][c] != 0 {
+				continue
+			}
+			if bits.OnesCount16(candidates[r][c]) == 1 {
+				digit := bits.TrailingZeros16(candidates[r][c]) + 1
+				return Step{
+					Rule:   NakedSingle,
+					Row:    r,
+					Col:    c,
+					Digit:  digit,
+					Reason: "only one candidate remains in the cell",
+				}, true
+			}
+		}
+	}
+	return Step{}, false
+}
+
+func findHiddenSingle(board [9][9]int, candidates [9][9]uint16) (Step, bool) {
+	for _, unit := range units() {
+		for digit := 1; digit <= 9; digit++ {
+			mask := uint16(1) << uint(digit-1)
+			var at [2]int
+			count := 0
+			for _, cell := range unit {
+				r, c := cell[0], cell[1]
+				if board[r][c] != 0 || candidates[r][c]&mask == 0 {
+					continue
+				}
+				count++
+				at = cell
+			}
+			if count == 1 {
+				return Step{
+					Rule:   HiddenSingle,
+					Row:    at[0],
+					Col:    at[1],
+					Digit:  digit,
+					Reason: "only cell in its unit that can hold this digit",
+				}, true
+			}
+		}
+	}
+	return Step{}, false
+}
+
+// eliminateNakedSubsets looks for N cells (N in 2, 3) within some unit
+// whose candidates' union has size N, and removes that union's digits
+// from every other cell in the unit. It stops and returns true as soon
+// as one such elimination actually changes a candidate.
+func eliminateNakedSubsets(board [9][9]int, candidates *[9][9]uint16, trace *Trace) bool {
+	for _, unit := range units() {
+		unsolved := unsolvedCells(board, unit)
+		for n := 2; n <= 3; n++ {
+			if n >= len(unsolved) {
+				continue
+			}
+			for _, subset := range combinations(unsolved, n) {
+				var union uint16
+				for _, cell := range subset {
+					union |= candidates[cell[0]][cell[1]]
+				}
+				if bits.OnesCount16(union) != n {
+					continue
+				}
+				if eliminateFromOthers(candidates, unit, subset, union, trace, NakedSubset,
+					"cells form a naked subset that fixes these digits among themselves") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// eliminatePointing looks, within each block, for a digit whose
+// remaining candidate cells all share a row or column, and removes it
+// from the rest of that row or column outside the block.
+func eliminatePointing(board [9][9]int, candidates *[9][9]uint16, trace *Trace) bool {
+	for br := 0; br < 3; br++ {
+		for bc := 0; bc < 3; bc++ {
+			block := blockCells(br, bc)
+			for digit := 1; digit <= 9; digit++ {
+				mask := uint16(1) << uint(digit-1)
+				rows, cols := map[int]bool{}, map[int]bool{}
+				var cells [][2]int
+				for _, cell := range block {
+					r, c := cell[0], cell[1]
+					if board[r][c] != 0 || candidates[r][c]&mask == 0 {
+						continue
+					}
+					rows[r] = true
+					cols[c] = true
+					cells = append(cells, cell)
+				}
+				if len(cells) == 0 {
+					continue
+				}
+				if len(rows) == 1 && eliminateFromOthers(candidates, rowUnit(cells[0][0]), cells, mask, trace,
+					PointingSubset, "digit confined to one row within its block") {
+					return true
+				}
+				if len(cols) == 1 && eliminateFromOthers(candidates, colUnit(cells[0][1]), cells, mask, trace,
+					PointingSubset, "digit confined to one column within its block") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// eliminateBoxLine is the inverse of eliminatePointing: for each
+// row/column, it looks for a digit whose remaining candidate cells all
+// share a block, and removes it from the rest of that block.
+func eliminateBoxLine(board [9][9]int, candidates *[9][9]uint16, trace *Trace) bool {
+	for i := 0; i < 9; i++ {
+		if boxLineForUnit(board, candidates, trace, rowUnit(i)) {
+			return true
+		}
+		if boxLineForUnit(board, candidates, trace, colUnit(i)) {
+			return true
+		}
+	}
+	return false
+}
+
+func boxLineForUnit(board [9][9]int, candidates *[9][9]uint16, trace *Trace, unit [][2]int) bool {
+	for digit := 1; digit <= 9; digit++ {
+		mask := uint16(1) << uint(digit-1)
+		blocks := map[int]bool{}
+		var cells [][2]int
+		for _, cell := range unit {
+			r, c := cell[0], cell[1]
+			if board[r][c] != 0 || candidates[r][c]&mask == 0 {
+				continue
+			}
+			blocks[blockIdx(r, c)] = true
+			cells = append(cells, cell)
+		}
+		if len(cells) == 0 || len(blocks) != 1 {
+			continue
+		}
+		block := blockCells(cells[0][0]/3, cells[0][1]/3)
+		if eliminateFromOthers(candidates, block, cells, mask, trace, BoxLineReduction,
+			"digit confined to one block within its row/column") {
+			return true
+		}
+	}
+	return false
+}
+
+// eliminateFromOthers clears mask from every cell in unit other than
+// those in exclude, recording one Step per candidate actually removed.
+// It reports whether anything was removed.
+func eliminateFromOthers(candidates *[9][9]uint16, unit, exclude [][2]int, mask uint16, trace *Trace, rule Rule, reason string) bool {
+	excluded := map[[2]int]bool{}
+	for _, cell := range exclude {
+		excluded[cell] = true
+	}
+
+	changed := false
+	for _, cell := range unit {
+		if excluded[cell] {
+			continue
+		}
+		r, c := cell[0], cell[1]
+		removed := candidates[r][c] & mask
+		if removed == 0 {
+			continue
+		}
+
+		candidates[r][c] &^= mask
+		changed = true
+		for digit := 1; digit <= 9; digit++ {
+			if removed&(1<<uint(digit-1)) == 0 {
+				continue
+			}
+			*trace = append(*trace, Step{Rule: rule, Row: r, Col: c, Digit: digit, Reason: reason})
+		}
+	}
+	return changed
+}
+
+func rowUnit(row int) [][2]int {
+	cells := make([][2]int, 9)
+	for c := 0; c < 9; c++ {
+		cells[c] = [2]int{row, c}
+	}
+	return cells
+}
+
+func colUnit(col int) [][2]int {
+	cells := make([][2]int, 9)
+	for r := 0; r < 9; r++ {
+		cells[r] = [2]int{r, col}
+	}
+	return cells
+}
+
+func blockCells(blockRow, blockCol int) [][2]int {
+	cells := make([][2]int, 0, 9)
+	for r := blockRow * 3; r < blockRow*3+3; r++ {
+		for c := blockCol * 3; c < blockCol*3+3; c++ {
+			cells = append(cells, [2]int{r, c})
+		}
+	}
+	return cells
+}
+
+func units() [][][2]int {
+	var us [][][2]int
+	for i := 0; i < 9; i++ {
+		us = append(us, rowUnit(i), colUnit(i))
+	}
+	for br := 0; br < 3; br++ {
+		for bc := 0; bc < 3; bc++ {
+			us = append(us, blockCells(br, bc))
+		}
+	}
+	return us
+}
+
+func unsolvedCells(board [9][9]int, unit [][2]int) [][2]int {
+	var out [][2]int
+	for _, cell := range unit {
+		if board[cell[0]][cell[1]] == 0 {
+			out = append(out, cell)
+		}
+	}
+	return out
+}
+
+// combinations returns every n-element subset of cells, in the order
+// their elements appear in cells.
+func combinations(cells [][2]int, n int) [][][2]int {
+	var out [][][2]int
+	var pick func(start int, chosen [][2]int)
+	pick = func(start int, chosen [][2]int) {
+		if len(chosen) == n {
+			out = append(out, append([][2]int(nil), chosen...))
+			return
+		}
+		for i := start; i < len(cells); i++ {
+			pick(i+1, append(chosen, cells[i]))
+		}
+	}
+	pick(0, nil)
+	return out
+}
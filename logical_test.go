@@ -0,0 +1,85 @@
+package sudokuconv_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jraedisch/sudokuconv"
+)
+
+func TestSolveLogicalSingles(t *testing.T) {
+	out, trace, err := sudokuconv.SolveLogical(solvablePuzzle)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(solvablePuzzleSolution, out) {
+		t.Errorf("unexpected solution:\n%v\n%v", solvablePuzzleSolution, out)
+	}
+	if len(trace) == 0 {
+		t.Error("expected at least one recorded step")
+	}
+	if got := trace.Difficulty(); got != sudokuconv.Easy {
+		t.Errorf("expected Easy difficulty, got %v", got)
+	}
+}
+
+// worldsHardestSudoku (Arto Inkala) cannot be solved by the logical
+// rules alone, so SolveLogical must fall back to Solve.
+var worldsHardestSudoku = [9][9]int{
+	{8, 0, 0, 0, 0, 0, 0, 0, 0},
+	{0, 0, 3, 6, 0, 0, 0, 0, 0},
+	{0, 7, 0, 0, 9, 0, 2, 0, 0},
+	{0, 5, 0, 0, 0, 7, 0, 0, 0},
+	{0, 0, 0, 0, 4, 5, 7, 0, 0},
+	{0, 0, 0, 1, 0, 0, 0, 3, 0},
+	{0, 0, 1, 0, 0, 0, 0, 6, 8},
+	{0, 0, 8, 5, 0, 0, 0, 1, 0},
+	{0, 9, 0, 0, 0, 0, 4, 0, 0},
+}
+
+func TestSolveLogicalFallsBackToSolve(t *testing.T) {
+	out, trace, err := sudokuconv.SolveLogical(worldsHardestSudoku)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want, err := sudokuconv.Solve(worldsHardestSudoku)
+	if err != nil {
+		t.Fatalf("unexpected error solving independently: %v", err)
+	}
+	if out != want {
+		t.Errorf("unexpected solution:\n%v\n%v", want, out)
+	}
+	if got := trace.Difficulty(); got != sudokuconv.Evil {
+		t.Errorf("expected Evil difficulty, got %v", got)
+	}
+}
+
+func TestSolveLogicalUnsolvable(t *testing.T) {
+	if _, _, err := sudokuconv.SolveLogical(unsolvablePuzzle); err != sudokuconv.ErrUnsolvable {
+		t.Errorf("expected ErrUnsolvable, got %v", err)
+	}
+}
+
+func TestSolveLogicalInvalidBoard(t *testing.T) {
+	if _, _, err := sudokuconv.SolveLogical(rowWithTwo9s); err == nil {
+		t.Error("expected error for board with duplicate digits")
+	}
+}
+
+func TestDifficultyString(t *testing.T) {
+	tests := []struct {
+		d    sudokuconv.Difficulty
+		want string
+	}{
+		{sudokuconv.Easy, "Easy"},
+		{sudokuconv.Medium, "Medium"},
+		{sudokuconv.Hard, "Hard"},
+		{sudokuconv.Evil, "Evil"},
+	}
+	for _, test := range tests {
+		if got := test.d.String(); got != test.want {
+			t.Errorf("Difficulty(%d).String() = %q, want %q", test.d, got, test.want)
+		}
+	}
+}
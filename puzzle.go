@@ -0,0 +1,213 @@
+package sudokuconv
+
+import (
+	"github.com/pkg/errors"
+)
+
+// partialFormat tags the byte layout produced by ToBytesPartial with a
+// header byte FromBytes can use to auto-dispatch between the two
+// layouts. It must be a value ToBytes can never produce at position 0:
+// ToBytes's first byte always has RowWith9Last (0-8) in its top
+// nibble, so a top nibble of 0xF can only mean the partial layout.
+const partialFormat byte = 0xFF
+
+// Puzzle represents a 9x9 sudoku that may be partially filled in.
+// Given holds the known values, with 0 marking a blank cell. It drives
+// both the solved and the partial encoding, picking whichever applies.
+type Puzzle struct {
+	Given [9][9]int
+}
+
+// ToBytes converts p into its compact byte representation, using the
+// solved encoding (see ToBytes) if Given has no blanks, or the partial
+// encoding (see ToBytesPartial) otherwise.
+func (p Puzzle) ToBytes() ([]byte, error) {
+	if p.isComplete() {
+		return ToBytes(p.Given)
+	}
+	return ToBytesPartial(p.Given)
+}
+
+func (p Puzzle) isComplete() bool {
+	return boardComplete(p.Given)
+}
+
+// boardComplete reports whether board has no blank (0) cells left.
+func boardComplete(board [9][9]int) bool {
+	for _, row := range board {
+		for _, val := range row {
+			if val == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ToBytesPartial converts a 9x9 sudoku board that may contain blank cells
+// (0) into a compact bit representation. The returned byte slice starts
+// with a format byte, followed by an 81-bit clue mask (one bit per cell,
+// set iff the cell is given, in row-major order) and then 4 bits for
+// every given cell's value, also in row-major order.
+// An error is returned iff some cell holds a value outside 0-9, or some
+// row, column or 3x3 block contains a duplicate non-zero digit.
+func ToBytesPartial(board [9][9]int) ([]byte, error) {
+	if !validatePartial(board) {
+		return nil, errors.New("board not valid")
+	}
+
+	bitLen := uint(8 + 81 + 4*countGiven(board))
+	out := make([]byte, byteSize(bitLen))
+	out[0] = partialFormat
+
+	bitIdx := uint(8)
+	for _, row := range board {
+		for _, val := range row {
+			setBit(out, bitIdx, val != 0)
+			bitIdx++
+		}
+	}
+	for _, row := range board {
+		for _, val := range row {
+			if val == 0 {
+				continue
+			}
+			writeBits(out, bitIdx, 4, uint8(val))
+			bitIdx += 4
+		}
+	}
+
+	return out, nil
+}
+
+// FromBytesPartial converts bytes (see ToBytesPartial) back to a board.
+// An error is returned iff the bytes are malformed, too short for the
+// clue mask they declare, or the embedded clues are invalid.
+func FromBytesPartial(bytes []byte) ([9][9]int, error) {
+	if len(bytes) < 1 || bytes[0] != partialFormat {
+		return [9][9]int{}, errors.New("not a partial-format payload")
+	}
+	if len(bytes)*8 < 8+81 {
+		return [9][9]int{}, errors.New("not enough bytes")
+	}
+
+	board := [9][9]int{}
+	given := [9][9]bool{}
+	count := 0
+
+	bitIdx := uint(8)
+	for rowIdx := range board {
+		for colIdx := range board[rowIdx] {
+			if getBit(bytes, bitIdx) {
+				given[rowIdx][colIdx] = true
+				count++
+			}
+			bitIdx++
+		}
+	}
+
+	if len(bytes)*8 < int(bitIdx)+4*count {
+		return [9][9]int{}, errors.New("not enough bytes")
+	}
+	for rowIdx := range board {
+		for colIdx := range board[rowIdx] {
+			if !given[rowIdx][colIdx] {
+				continue
+			}
+			board[rowIdx][colIdx] = int(readBits(bytes, bitIdx, 4))
+			bitIdx += 4
+		}
+	}
+
+	if _, err := Solve(board); err != nil && err != ErrMultipleSolutions {
+		return [9][9]int{}, errors.Wrap(err, "bytes lead to incorrect board")
+	}
+
+	return board, nil
+}
+
+// validatePartial is a relaxed form of validate: blanks (0) are allowed
+// and rows/columns/blocks need not be complete, but any non-zero digit
+// that does appear must not be duplicated within its row, column or
+// 3x3 block.
+func validatePartial(board [9][9]int) bool {
+	for _, row := range board {
+		if !validateGroupPartial(row) {
+			return false
+		}
+	}
+	for colIdx := 0; colIdx < 9; colIdx++ {
+		if !validateGroupPartial(extractCol(board, colIdx)) {
+			return false
+		}
+	}
+	for x := 0; x < 3; x++ {
+		for y := 0; y < 3; y++ {
+			if !validateGroupPartial(extractGrid(board, x, y)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func validateGroupPartial(group [9]int) bool {
+	var seen uint16
+	for _, val := range group {
+		if val == 0 {
+			continue
+		}
+		if val < 1 || val > 9 {
+			return false
+		}
+		mask := uint16(1) << uint(val-1)
+		if seen&mask != 0 {
+			return false
+		}
+		seen |= mask
+	}
+	return true
+}
+
+func countGiven(board [9][9]int) int {
+	count := 0
+	for _, row := range board {
+		for _, val := range row {
+			if val != 0 {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// setBit sets bit bitIdx (counted from the most significant bit of
+// bytes[0]) iff val is true. Bits default to 0, so false is a no-op.
+func setBit(bytes []byte, bitIdx uint, val bool) {
+	if !val {
+		return
+	}
+	bytes[bitIdx/8] |= bitMasks[bitIdx%8]
+}
+
+func getBit(bytes []byte, bitIdx uint) bool {
+	return bytes[bitIdx/8]&bitMasks[bitIdx%8] != 0
+}
+
+// writeBits writes the n least significant bits of val at bitIdx,
+// most significant bit first.
+func writeBits(bytes []byte, bitIdx uint, n uint, val uint8) {
+	for i := uint(0); i < n; i++ {
+		setBit(bytes, bitIdx+i, val&(1<<(n-1-i)) != 0)
+	}
+}
+
+func readBits(bytes []byte, bitIdx uint, n uint) uint8 {
+	var val uint8
+	for i := uint(0); i < n; i++ {
+		if getBit(bytes, bitIdx+i) {
+			val |= 1 << (n - 1 - i)
+		}
+	}
+	return val
+}
@@ -0,0 +1,116 @@
+package sudokuconv_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jraedisch/sudokuconv"
+)
+
+var partialWorking = [9][9]int{
+	{9, 8, 7, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 3, 2, 1, 9, 8, 7},
+	{0, 0, 0, 9, 8, 7, 6, 5, 4},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0},
+}
+
+var partialEmpty = [9][9]int{}
+
+var partialWithDuplicateInRow = [9][9]int{
+	{9, 9, 0, 0, 0, 0, 0, 0, 0},
+}
+
+func TestToBytesPartialRoundtrip(t *testing.T) {
+	tests := []struct {
+		id string
+		in [9][9]int
+	}{
+		{id: "working", in: partialWorking},
+		{id: "empty", in: partialEmpty},
+	}
+	for _, test := range tests {
+		bytes, err := sudokuconv.ToBytesPartial(test.in)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.id, err)
+			continue
+		}
+		out, err := sudokuconv.FromBytesPartial(bytes)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.id, err)
+			continue
+		}
+		if !reflect.DeepEqual(test.in, out) {
+			t.Errorf("%s: roundtrip mismatch:\n%v\n%v", test.id, test.in, out)
+		}
+	}
+}
+
+func TestToBytesPartialInvalid(t *testing.T) {
+	if _, err := sudokuconv.ToBytesPartial(partialWithDuplicateInRow); err == nil {
+		t.Error("expected error for duplicate digit in row")
+	}
+}
+
+func TestFromBytesPartialMalformed(t *testing.T) {
+	tests := []struct {
+		id string
+		in []byte
+	}{
+		{id: "empty", in: []byte{}},
+		{id: "wrong format byte", in: []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{id: "too short", in: []byte{0xFF, 2, 3}},
+	}
+	for _, test := range tests {
+		if _, err := sudokuconv.FromBytesPartial(test.in); err == nil {
+			t.Errorf("%s: expected error", test.id)
+		}
+	}
+}
+
+func TestFromBytesDispatchesToPartial(t *testing.T) {
+	bytes, err := sudokuconv.ToBytesPartial(partialWorking)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out, err := sudokuconv.FromBytes(bytes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(partialWorking, out) {
+		t.Errorf("unexpected board:\n%v\n%v", partialWorking, out)
+	}
+}
+
+func TestPuzzleToBytes(t *testing.T) {
+	partial := sudokuconv.Puzzle{Given: partialWorking}
+	bytes, err := partial.ToBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := sudokuconv.FromBytesPartial(bytes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(partialWorking, out) {
+		t.Errorf("roundtrip mismatch:\n%v\n%v", partialWorking, out)
+	}
+
+	solved := sudokuconv.Puzzle{Given: working}
+	bytes, err = solved.ToBytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	solvedOut, err := sudokuconv.FromBytes(bytes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(working, solvedOut) {
+		t.Errorf("roundtrip mismatch:\n%v\n%v", working, solvedOut)
+	}
+}
@@ -0,0 +1,251 @@
+package sudokuconv
+
+import "github.com/pkg/errors"
+
+// rsGeneratorPoly builds the Reed-Solomon generator polynomial for a
+// code with the given number of parity symbols, g(x) = prod_{i=0}^{parity-1}
+// (x - alpha^i). Coefficients are in descending degree order, matching
+// rsEncode and polyEval.
+func rsGeneratorPoly(parity int) []byte {
+	g := []byte{1}
+	for i := 0; i < parity; i++ {
+		g = polyMul(g, []byte{1, gfPow(2, i)})
+	}
+	return g
+}
+
+// polyMul multiplies two polynomials with coefficients in descending
+// degree order over GF(256).
+func polyMul(a, b []byte) []byte {
+	out := make([]byte, len(a)+len(b)-1)
+	for i, ac := range a {
+		if ac == 0 {
+			continue
+		}
+		for j, bc := range b {
+			out[i+j] ^= gfMul(ac, bc)
+		}
+	}
+	return out
+}
+
+// polyEval evaluates a polynomial with coefficients in descending degree
+// order (poly[0] is the highest-degree coefficient) at x via Horner's
+// method.
+func polyEval(poly []byte, x byte) byte {
+	var result byte
+	for _, c := range poly {
+		result = gfMul(result, x) ^ c
+	}
+	return result
+}
+
+// rsEncode appends parity Reed-Solomon parity symbols to msg (message
+// bytes, highest-order byte first), computed by polynomial division of
+// msg shifted left by parity bytes by the generator polynomial.
+func rsEncode(msg []byte, parity int) []byte {
+	gen := rsGeneratorPoly(parity)
+
+	remainder := make([]byte, len(msg)+parity)
+	copy(remainder, msg)
+	for i := 0; i < len(msg); i++ {
+		coef := remainder[i]
+		if coef == 0 {
+			continue
+		}
+		for j, gc := range gen {
+			remainder[i+j] ^= gfMul(gc, coef)
+		}
+	}
+
+	out := make([]byte, len(msg)+parity)
+	copy(out, msg)
+	copy(out[len(msg):], remainder[len(msg):])
+	return out
+}
+
+// rsDecode corrects up to parity/2 byte errors in codeword (as produced
+// by rsEncode) using syndrome decoding: syndromes are computed from the
+// received word, Berlekamp-Massey finds the error locator polynomial,
+// Chien search finds the error positions, and Forney's formula finds
+// the error magnitudes. It returns the original message with the
+// trailing parity symbols stripped, or an error if the errors present
+// exceed the code's correction capacity.
+func rsDecode(codeword []byte, parity int) ([]byte, error) {
+	if len(codeword) <= parity {
+		return nil, errors.New("codeword shorter than its parity")
+	}
+
+	syndromes := rsSyndromes(codeword, parity)
+	if allZero(syndromes) {
+		return codeword[:len(codeword)-parity], nil
+	}
+
+	errLoc := berlekampMassey(syndromes)
+	numErrors := len(errLoc) - 1
+	if numErrors > parity/2 {
+		return nil, errors.New("too many errors to correct")
+	}
+
+	roots, err := chienSearch(errLoc, len(codeword))
+	if err != nil {
+		return nil, err
+	}
+
+	corrected := append([]byte(nil), codeword...)
+	if err := forneyCorrect(corrected, syndromes, errLoc, roots); err != nil {
+		return nil, err
+	}
+
+	if !allZero(rsSyndromes(corrected, parity)) {
+		return nil, errors.New("uncorrectable errors")
+	}
+
+	return corrected[:len(corrected)-parity], nil
+}
+
+// rsSyndromes evaluates codeword at alpha^0..alpha^(parity-1); all are
+// zero iff codeword is a valid (uncorrupted) codeword.
+func rsSyndromes(codeword []byte, parity int) []byte {
+	syndromes := make([]byte, parity)
+	for i := range syndromes {
+		syndromes[i] = polyEval(codeword, gfPow(2, i))
+	}
+	return syndromes
+}
+
+func allZero(bs []byte) bool {
+	for _, b := range bs {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// berlekampMassey finds the error locator polynomial: the
+// lowest-degree sigma, with sigma[0] == 1, such that
+// sum_{i=0}^{l} sigma[i]*syndromes[n-i] == 0 for every n. Coefficients
+// are in ascending degree order.
+func berlekampMassey(syndromes []byte) []byte {
+	n := len(syndromes)
+	c := make([]byte, n+1)
+	b := make([]byte, n+1)
+	c[0], b[0] = 1, 1
+
+	l, m := 0, 1
+	bCoef := byte(1)
+
+	for i := 0; i < n; i++ {
+		delta := syndromes[i]
+		for j := 1; j <= l; j++ {
+			delta ^= gfMul(c[j], syndromes[i-j])
+		}
+		if delta == 0 {
+			m++
+			continue
+		}
+
+		t := append([]byte(nil), c...)
+		coef := gfDiv(delta, bCoef)
+		for j := 0; j+m < len(c); j++ {
+			c[j+m] ^= gfMul(coef, b[j])
+		}
+
+		if 2*l <= i {
+			l = i + 1 - l
+			b = t
+			bCoef = delta
+			m = 1
+		} else {
+			m++
+		}
+	}
+	return c[:l+1]
+}
+
+// errRoot pairs an error position in the codeword with the inverse of
+// its locator value, alpha^-i, which Forney's formula evaluates at.
+type errRoot struct {
+	pos  int
+	xInv byte
+}
+
+// chienSearch brute-force evaluates errLoc at the inverse of every
+// field element to find its roots, which mark the error positions
+// within a codeword of length n.
+func chienSearch(errLoc []byte, n int) ([]errRoot, error) {
+	var roots []errRoot
+	for i := 0; i < n; i++ {
+		xInv := gfInv(gfPow(2, i))
+		if polyEvalAscending(errLoc, xInv) == 0 {
+			roots = append(roots, errRoot{pos: n - 1 - i, xInv: xInv})
+		}
+	}
+	if len(roots) != len(errLoc)-1 {
+		return nil, errors.New("error locator roots do not match error count")
+	}
+	return roots, nil
+}
+
+// polyEvalAscending evaluates a polynomial with coefficients in
+// ascending degree order (poly[0] is the constant term) at x.
+func polyEvalAscending(poly []byte, x byte) byte {
+	var result byte
+	xPow := byte(1)
+	for _, c := range poly {
+		result ^= gfMul(c, xPow)
+		xPow = gfMul(xPow, x)
+	}
+	return result
+}
+
+// forneyCorrect applies Forney's formula at each root to recover the
+// error magnitude and XORs it into codeword in place.
+func forneyCorrect(codeword []byte, syndromes []byte, errLoc []byte, roots []errRoot) error {
+	omega := polyMulAscending(syndromes, errLoc)
+	if len(omega) > len(syndromes) {
+		omega = omega[:len(syndromes)]
+	}
+	derivative := polyDerivativeAscending(errLoc)
+
+	for _, root := range roots {
+		denom := polyEvalAscending(derivative, root.xInv)
+		if denom == 0 {
+			return errors.New("error locator derivative vanishes at a root")
+		}
+		// Forney's formula: e_k = X_k * Omega(X_k^-1) / sigma'(X_k^-1).
+		magnitude := gfMul(gfDiv(polyEvalAscending(omega, root.xInv), denom), gfInv(root.xInv))
+		codeword[root.pos] ^= magnitude
+	}
+	return nil
+}
+
+// polyMulAscending multiplies two polynomials with coefficients in
+// ascending degree order over GF(256).
+func polyMulAscending(a, b []byte) []byte {
+	out := make([]byte, len(a)+len(b)-1)
+	for i, ac := range a {
+		if ac == 0 {
+			continue
+		}
+		for j, bc := range b {
+			out[i+j] ^= gfMul(ac, bc)
+		}
+	}
+	return out
+}
+
+// polyDerivativeAscending computes the formal derivative of a
+// polynomial with coefficients in ascending degree order over GF(2^8):
+// since field characteristic is 2, every even-degree term vanishes.
+func polyDerivativeAscending(p []byte) []byte {
+	if len(p) <= 1 {
+		return nil
+	}
+	out := make([]byte, len(p)-1)
+	for i := 1; i < len(p); i += 2 {
+		out[i-1] = p[i]
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+package sudokuconv
+
+import "github.com/pkg/errors"
+
+// ErrUnsolvable is returned by Solve when the given board admits no
+// completion that satisfies the row/column/block constraints.
+var ErrUnsolvable = errors.New("board is unsolvable")
+
+// ErrMultipleSolutions is returned by Solve when the given board admits
+// more than one such completion.
+var ErrMultipleSolutions = errors.New("board has multiple solutions")
+
+// Solve completes a 9x9 sudoku board via backtracking search, trying
+// digits 1-9 against incrementally maintained per-row/column/block
+// bitmasks and backtracking on dead ends. Blank cells must be 0; filled
+// cells are treated as fixed clues. It returns ErrUnsolvable if no
+// completion exists, and ErrMultipleSolutions if more than one does.
+func Solve(board [9][9]int) ([9][9]int, error) {
+	if !validatePartial(board) {
+		return [9][9]int{}, errors.New("board not valid")
+	}
+
+	rows, cols, blocks := occupancyMasks(board)
+	found := 0
+	var first [9][9]int
+	solve(&board, &rows, &cols, &blocks, &found, &first)
+
+	switch found {
+	case 0:
+		return [9][9]int{}, ErrUnsolvable
+	case 1:
+		return first, nil
+	default:
+		return [9][9]int{}, ErrMultipleSolutions
+	}
+}
+
+// occupancyMasks builds, for every row/column/block, a bitmask with bit
+// i set iff digit i+1 is already placed somewhere in it.
+func occupancyMasks(board [9][9]int) (rows, cols, blocks [9]uint16) {
+	for r := 0; r < 9; r++ {
+		for c := 0; c < 9; c++ {
+			val := board[r][c]
+			if val == 0 {
+				continue
+			}
+			mask := uint16(1) << uint(val-1)
+			rows[r] |= mask
+			cols[c] |= mask
+			blocks[blockIdx(r, c)] |= mask
+		}
+	}
+	return
+}
+
+func blockIdx(row, col int) int {
+	return (row/3)*3 + col/3
+}
+
+// solve recurses over board's blank cells in row-major order. It stops
+// as soon as two solutions are found, since Solve only cares whether the
+// completion is unique; the first solution encountered is copied to
+// *first and found is incremented once per solution reached.
+func solve(board *[9][9]int, rows, cols, blocks *[9]uint16, found *int, first *[9][9]int) bool {
+	row, col, ok := nextBlank(*board)
+	if !ok {
+		*found++
+		if *found == 1 {
+			*first = *board
+		}
+		return *found >= 2
+	}
+
+	block := blockIdx(row, col)
+	used := rows[row] | cols[col] | blocks[block]
+	for digit := 1; digit <= 9; digit++ {
+		mask := uint16(1) << uint(digit-1)
+		if used&mask != 0 {
+			continue
+		}
+
+		board[row][col] = digit
+		rows[row] |= mask
+		cols[col] |= mask
+		blocks[block] |= mask
+
+		stop := solve(board, rows, cols, blocks, found, first)
+
+		rows[row] &^= mask
+		cols[col] &^= mask
+		blocks[block] &^= mask
+		board[row][col] = 0
+
+		if stop {
+			return true
+		}
+	}
+	return false
+}
+
+func nextBlank(board [9][9]int) (row, col int, ok bool) {
+	for r := 0; r < 9; r++ {
+		for c := 0; c < 9; c++ {
+			if board[r][c] == 0 {
+				return r, c, true
+			}
+		}
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,79 @@
+package sudokuconv_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jraedisch/sudokuconv"
+)
+
+var solvablePuzzle = [9][9]int{
+	{5, 3, 0, 0, 7, 0, 0, 0, 0},
+	{6, 0, 0, 1, 9, 5, 0, 0, 0},
+	{0, 9, 8, 0, 0, 0, 0, 6, 0},
+	{8, 0, 0, 0, 6, 0, 0, 0, 3},
+	{4, 0, 0, 8, 0, 3, 0, 0, 1},
+	{7, 0, 0, 0, 2, 0, 0, 0, 6},
+	{0, 6, 0, 0, 0, 0, 2, 8, 0},
+	{0, 0, 0, 4, 1, 9, 0, 0, 5},
+	{0, 0, 0, 0, 8, 0, 0, 7, 9},
+}
+
+var solvablePuzzleSolution = [9][9]int{
+	{5, 3, 4, 6, 7, 8, 9, 1, 2},
+	{6, 7, 2, 1, 9, 5, 3, 4, 8},
+	{1, 9, 8, 3, 4, 2, 5, 6, 7},
+	{8, 5, 9, 7, 6, 1, 4, 2, 3},
+	{4, 2, 6, 8, 5, 3, 7, 9, 1},
+	{7, 1, 3, 9, 2, 4, 8, 5, 6},
+	{9, 6, 1, 5, 3, 7, 2, 8, 4},
+	{2, 8, 7, 4, 1, 9, 6, 3, 5},
+	{3, 4, 5, 2, 8, 6, 1, 7, 9},
+}
+
+// unsolvablePuzzle has no duplicate digits in any row, column or block,
+// but forces a contradiction: (0,8) is the only blank in row 0 and must
+// be 9, yet the block it belongs to already has a 9 at (1,8).
+var unsolvablePuzzle = [9][9]int{
+	{1, 2, 3, 4, 5, 6, 7, 8, 0},
+	{0, 0, 0, 0, 0, 0, 0, 0, 9},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0},
+}
+
+func TestSolve(t *testing.T) {
+	out, err := sudokuconv.Solve(solvablePuzzle)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(solvablePuzzleSolution, out) {
+		t.Errorf("unexpected solution:\n%v\n%v", solvablePuzzleSolution, out)
+	}
+}
+
+func TestSolveAlreadySolved(t *testing.T) {
+	out, err := sudokuconv.Solve(working)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(working, out) {
+		t.Errorf("unexpected solution:\n%v\n%v", working, out)
+	}
+}
+
+func TestSolveUnsolvable(t *testing.T) {
+	if _, err := sudokuconv.Solve(unsolvablePuzzle); err != sudokuconv.ErrUnsolvable {
+		t.Errorf("expected ErrUnsolvable, got %v", err)
+	}
+}
+
+func TestSolveMultipleSolutions(t *testing.T) {
+	if _, err := sudokuconv.Solve(emptyBoard); err != sudokuconv.ErrMultipleSolutions {
+		t.Errorf("expected ErrMultipleSolutions, got %v", err)
+	}
+}
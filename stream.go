@@ -0,0 +1,150 @@
+package sudokuconv
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// minEncodedLen and maxEncodedLen bound ToBytes's output: 23 bytes when
+// the last row's 9 lands in the last column and none of the cells (0,0),
+// (0,3), (3,0) and (3,3) holds a 9, 24 bytes otherwise.
+const (
+	minEncodedLen = 23
+	maxEncodedLen = 24
+)
+
+// Encoder writes a stream of solved boards in ToBytes's compact
+// format, framed with a one-bit length tag per record (packed 8 per
+// framing byte, set iff the record is the longer 24-byte encoding) so
+// a Decoder can tell each record's length apart without a full length
+// prefix.
+type Encoder struct {
+	w        io.Writer
+	buffered [][]byte
+}
+
+// NewEncoder returns an Encoder that writes boards to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes board to the stream. Records are buffered 8 at a time
+// so a full framing byte's worth of length tags can be written ahead
+// of the records they describe; once 8 are buffered, the framing byte
+// and the records are written together. Call Flush to write out a
+// partial batch.
+func (e *Encoder) Encode(board [9][9]int) error {
+	bytes, err := ToBytes(board)
+	if err != nil {
+		return err
+	}
+
+	e.buffered = append(e.buffered, bytes)
+	if len(e.buffered) == 8 {
+		return e.flush()
+	}
+	return nil
+}
+
+// Flush writes out any buffered boards as a partial framing byte, so
+// they reach w without waiting for 8 boards to accumulate. A Decoder
+// reads a new framing byte only after 8 records, so a partial framing
+// byte is read correctly only at the end of the stream; boards encoded
+// after a Flush that wrote a partial batch are not decoded correctly.
+func (e *Encoder) Flush() error {
+	if len(e.buffered) == 0 {
+		return nil
+	}
+	return e.flush()
+}
+
+func (e *Encoder) flush() error {
+	var frame byte
+	for i, bytes := range e.buffered {
+		if len(bytes) == maxEncodedLen {
+			frame |= bitMasks[i]
+		}
+	}
+
+	if _, err := e.w.Write([]byte{frame}); err != nil {
+		return err
+	}
+	for _, bytes := range e.buffered {
+		if _, err := e.w.Write(bytes); err != nil {
+			return err
+		}
+	}
+	e.buffered = e.buffered[:0]
+	return nil
+}
+
+// EncodeAll writes every board in boards to w, flushing any partial
+// framing byte at the end.
+func EncodeAll(w io.Writer, boards [][9][9]int) error {
+	enc := NewEncoder(w)
+	for _, board := range boards {
+		if err := enc.Encode(board); err != nil {
+			return err
+		}
+	}
+	return enc.Flush()
+}
+
+// Decoder reads a stream of solved boards written by Encoder.
+type Decoder struct {
+	r     io.Reader
+	frame byte
+	idx   uint // next bit to consult in frame; 8 means a fresh frame byte is needed
+}
+
+// NewDecoder returns a Decoder that reads boards from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r, idx: 8}
+}
+
+// Decode reads the next board from the stream. It returns io.EOF, and
+// no board, once the stream is exhausted at a record boundary.
+func (d *Decoder) Decode() ([9][9]int, error) {
+	if d.idx == 8 {
+		frame := make([]byte, 1)
+		if _, err := io.ReadFull(d.r, frame); err != nil {
+			return [9][9]int{}, err
+		}
+		d.frame = frame[0]
+		d.idx = 0
+	}
+
+	length := minEncodedLen
+	if d.frame&bitMasks[d.idx] != 0 {
+		length = maxEncodedLen
+	}
+	d.idx++
+
+	record := make([]byte, length)
+	if _, err := io.ReadFull(d.r, record); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return [9][9]int{}, errors.Wrap(err, "stream ended mid-record")
+		}
+		return [9][9]int{}, err
+	}
+
+	return FromBytes(record)
+}
+
+// DecodeAll reads every board from r until the stream is exhausted.
+func DecodeAll(r io.Reader) ([][9][9]int, error) {
+	dec := NewDecoder(r)
+
+	var boards [][9][9]int
+	for {
+		board, err := dec.Decode()
+		if err == io.EOF {
+			return boards, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		boards = append(boards, board)
+	}
+}
@@ -0,0 +1,108 @@
+package sudokuconv_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/jraedisch/sudokuconv"
+)
+
+func TestEncodeAllDecodeAll(t *testing.T) {
+	boards := [][9][9]int{working, working9last, working9firstOf2Grids, workingIdeal9s}
+
+	var buf bytes.Buffer
+	if err := sudokuconv.EncodeAll(&buf, boards); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out, err := sudokuconv.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(boards, out) {
+		t.Errorf("unexpected boards:\n%v\n%v", boards, out)
+	}
+}
+
+func TestEncoderFlushPartialBatch(t *testing.T) {
+	boards := [][9][9]int{working, working9last, workingIdeal9s}
+
+	var buf bytes.Buffer
+	enc := sudokuconv.NewEncoder(&buf)
+	for _, board := range boards {
+		if err := enc.Encode(board); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dec := sudokuconv.NewDecoder(&buf)
+	for i, want := range boards {
+		got, err := dec.Decode()
+		if err != nil {
+			t.Fatalf("board %d: unexpected error: %v", i, err)
+		}
+		if got != want {
+			t.Errorf("board %d: unexpected board:\n%v\n%v", i, want, got)
+		}
+	}
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("expected io.EOF after the last board, got %v", err)
+	}
+}
+
+func TestEncoderEncodeInvalidBoard(t *testing.T) {
+	var buf bytes.Buffer
+	enc := sudokuconv.NewEncoder(&buf)
+	if err := enc.Encode(emptyBoard); err == nil {
+		t.Error("expected error encoding an unsolved board")
+	}
+}
+
+func TestDecoderEmptyStream(t *testing.T) {
+	dec := sudokuconv.NewDecoder(&bytes.Buffer{})
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestDecoderTruncatedStream(t *testing.T) {
+	var buf bytes.Buffer
+	enc := sudokuconv.NewEncoder(&buf)
+	if err := enc.Encode(working); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	truncated := bytes.NewReader(buf.Bytes()[:buf.Len()-1])
+	dec := sudokuconv.NewDecoder(truncated)
+	if _, err := dec.Decode(); err == nil || err == io.EOF {
+		t.Errorf("expected a non-EOF error for a truncated record, got %v", err)
+	}
+}
+
+func TestEncodeAllManyBoards(t *testing.T) {
+	boards := make([][9][9]int, 20)
+	for i := range boards {
+		boards[i] = working
+	}
+
+	var buf bytes.Buffer
+	if err := sudokuconv.EncodeAll(&buf, boards); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out, err := sudokuconv.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(boards, out) {
+		t.Errorf("decoded boards differ from encoded ones: got %d boards, want %d", len(out), len(boards))
+	}
+}
@@ -49,9 +49,16 @@ func byteSize(bitSize uint) int {
 	return int(math.Ceil(float64(bitSize) / 8))
 }
 
-// FromBytes converts bytes (see ToBytes) back to board.
+// FromBytes converts bytes (see ToBytes) back to board. It also
+// auto-dispatches to the partial layout (see ToBytesPartial) when
+// bytes carries that format's reserved header byte, so callers that
+// don't know ahead of time whether a payload is a full solution or a
+// partially filled puzzle can always call FromBytes.
 // An error is returned iff the provided bytes are malformed.
 func FromBytes(bytes []byte) ([9][9]int, error) {
+	if len(bytes) >= 1 && bytes[0] == partialFormat {
+		return FromBytesPartial(bytes)
+	}
 	if len(bytes) < 9 {
 		return [9][9]int{}, errors.New("not enough bytes")
 	}
@@ -0,0 +1,90 @@
+package sudokuconv
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// ParseText parses the grid-with-separators layout commonly used for
+// sudoku puzzles: nine lines of nine tokens, "." or "0" for blanks,
+// with optional "|" column separators every 3 cells and
+// "+---+---+---+"-style dividers every 3 rows. Parsing is lenient:
+// '|', '+', '-' and whitespace are ignored, so both the decorated and
+// the bare grid are accepted.
+func ParseText(s string) ([9][9]int, error) {
+	var digits []int
+	for _, r := range s {
+		switch {
+		case r == '.' || r == '0':
+			digits = append(digits, 0)
+		case r >= '1' && r <= '9':
+			digits = append(digits, int(r-'0'))
+		case r == '|' || r == '+' || r == '-' || unicode.IsSpace(r):
+			continue
+		default:
+			return [9][9]int{}, errors.Errorf("unexpected character %q", r)
+		}
+	}
+	return digitsToBoard(digits)
+}
+
+// ParseLine parses the common one-line 81-character encoding of a
+// sudoku board: "." or "0" for blanks, "1"-"9" otherwise, with no
+// separators or whitespace.
+func ParseLine(s string) ([9][9]int, error) {
+	digits := make([]int, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '.' || r == '0':
+			digits = append(digits, 0)
+		case r >= '1' && r <= '9':
+			digits = append(digits, int(r-'0'))
+		default:
+			return [9][9]int{}, errors.Errorf("unexpected character %q", r)
+		}
+	}
+	return digitsToBoard(digits)
+}
+
+func digitsToBoard(digits []int) ([9][9]int, error) {
+	if len(digits) != 81 {
+		return [9][9]int{}, errors.Errorf("expected 81 cells, got %d", len(digits))
+	}
+	var board [9][9]int
+	for i, d := range digits {
+		board[i/9][i%9] = d
+	}
+	return board, nil
+}
+
+// FormatText renders board in the canonical boxed form: nine lines of
+// nine tokens ("." for blanks), with "|" separating 3-cell column
+// groups and "+---+---+---+" dividers between 3-row bands.
+func FormatText(board [9][9]int) string {
+	const divider = "+---+---+---+"
+
+	var b strings.Builder
+	for rowIdx, row := range board {
+		if rowIdx%3 == 0 {
+			b.WriteString(divider)
+			b.WriteByte('\n')
+		}
+		for colIdx, val := range row {
+			if colIdx%3 == 0 {
+				b.WriteByte('|')
+			}
+			if val == 0 {
+				b.WriteByte('.')
+			} else {
+				b.WriteByte(byte('0' + val))
+			}
+		}
+		b.WriteString("|\n")
+	}
+	b.WriteString(divider)
+	b.WriteByte('\n')
+
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package sudokuconv_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jraedisch/sudokuconv"
+)
+
+const boxedText = `+---+---+---+
+|9.7|654|321|
+|654|321|987|
+|321|987|654|
++---+---+---+
+|896|745|213|
+|745|213|896|
+|213|896|745|
++---+---+---+
+|579|468|132|
+|468|132|579|
+|132|579|468|
++---+---+---+
+`
+
+var boxedBoard = [9][9]int{
+	{9, 0, 7, 6, 5, 4, 3, 2, 1},
+	{6, 5, 4, 3, 2, 1, 9, 8, 7},
+	{3, 2, 1, 9, 8, 7, 6, 5, 4},
+	{8, 9, 6, 7, 4, 5, 2, 1, 3},
+	{7, 4, 5, 2, 1, 3, 8, 9, 6},
+	{2, 1, 3, 8, 9, 6, 7, 4, 5},
+	{5, 7, 9, 4, 6, 8, 1, 3, 2},
+	{4, 6, 8, 1, 3, 2, 5, 7, 9},
+	{1, 3, 2, 5, 7, 9, 4, 6, 8},
+}
+
+func TestParseText(t *testing.T) {
+	out, err := sudokuconv.ParseText(boxedText)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(boxedBoard, out) {
+		t.Errorf("unexpected board:\n%v\n%v", boxedBoard, out)
+	}
+}
+
+func TestParseTextBareGrid(t *testing.T) {
+	bare := "9.7654321\n" +
+		"654321987\n" +
+		"321987654\n" +
+		"896745213\n" +
+		"745213896\n" +
+		"213896745\n" +
+		"579468132\n" +
+		"468132579\n" +
+		"132579468\n"
+	out, err := sudokuconv.ParseText(bare)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(boxedBoard, out) {
+		t.Errorf("unexpected board:\n%v\n%v", boxedBoard, out)
+	}
+}
+
+func TestParseTextWrongCellCount(t *testing.T) {
+	if _, err := sudokuconv.ParseText("123"); err == nil {
+		t.Error("expected error for too few cells")
+	}
+}
+
+func TestParseTextUnexpectedCharacter(t *testing.T) {
+	if _, err := sudokuconv.ParseText("a23456789456789123789123456234567891567891234891234567345678912678912345912345678"); err == nil {
+		t.Error("expected error for unexpected character")
+	}
+}
+
+func TestFormatText(t *testing.T) {
+	if out := sudokuconv.FormatText(boxedBoard); out != boxedText {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", out, boxedText)
+	}
+}
+
+func TestParseLine(t *testing.T) {
+	line := "9.7654321654321987321987654896745213745213896213896745579468132468132579132579468"
+	out, err := sudokuconv.ParseLine(line)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(boxedBoard, out) {
+		t.Errorf("unexpected board:\n%v\n%v", boxedBoard, out)
+	}
+}
+
+func TestParseLineWrongLength(t *testing.T) {
+	if _, err := sudokuconv.ParseLine("123"); err == nil {
+		t.Error("expected error for wrong length")
+	}
+}